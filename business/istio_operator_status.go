@@ -0,0 +1,220 @@
+package business
+
+import (
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/watch"
+
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/log"
+)
+
+// Watches on Kubernetes resources close routinely (API server restarts, idle timeouts, network
+// blips); watch() reopens the watch with this backoff instead of giving up after the first
+// disconnect, doubling up to watchMaxBackoff.
+const (
+	watchInitialBackoff = 1 * time.Second
+	watchMaxBackoff     = 30 * time.Second
+)
+
+// Istio operator reconciler status values, as published on IstioOperator.status.componentStatus.
+// See https://github.com/istio/istio/blob/master/operator/pkg/apis/istio/v1alpha1/shared_types.go
+const (
+	operatorHealthy     = "HEALTHY"
+	operatorReconciling = "RECONCILING"
+	operatorError       = "ERROR"
+)
+
+// operatorProviders caches one OperatorStatusProvider per cluster, created lazily the first time
+// that cluster's status is requested. A package-level map (rather than a field threaded through
+// every IstioStatusService) keeps the watch alive across separate GetStatus calls, which is the
+// point of caching it in the first place.
+var (
+	operatorProviders   = map[string]*OperatorStatusProvider{}
+	operatorProvidersMu sync.Mutex
+)
+
+func getOperatorStatusProvider(cluster string, client kubernetes.ClientInterface) *OperatorStatusProvider {
+	operatorProvidersMu.Lock()
+	defer operatorProvidersMu.Unlock()
+
+	if p, found := operatorProviders[cluster]; found {
+		return p
+	}
+
+	p := newOperatorStatusProvider(cluster, client)
+	operatorProviders[cluster] = p
+	return p
+}
+
+// OperatorStatusProvider reads the componentStatus the Istio operator reconciler publishes on the
+// IstioOperator CR, and uses it in preference to inferring component health from Deployments. This
+// matters when Istio was installed via the operator/istioctl with non-default component names or
+// namespaces, where a plain Deployment scan wouldn't know where to look.
+//
+// It watches the CR for changes and keeps the last known status cached, so a slow or momentarily
+// unreachable API server doesn't make every status check pay for a fresh CR read.
+type OperatorStatusProvider struct {
+	client  kubernetes.ClientInterface
+	cluster string
+
+	mu      sync.RWMutex
+	cached  IstioComponentStatus
+	present bool
+}
+
+func newOperatorStatusProvider(cluster string, client kubernetes.ClientInterface) *OperatorStatusProvider {
+	p := &OperatorStatusProvider{client: client, cluster: cluster}
+	p.refresh()
+	go p.watch()
+	return p
+}
+
+// ComponentStatus returns a copy of the last known operator-derived status, and whether an
+// IstioOperator CR was present at all. When it isn't present, getIstioComponentStatus should fall
+// back to the Deployment-based scan. The slice is copied out from under the lock so callers can
+// freely read or mutate it without racing refresh(), which replaces p.cached wholesale from the
+// watch goroutine.
+func (p *OperatorStatusProvider) ComponentStatus() (IstioComponentStatus, bool) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	cached := make(IstioComponentStatus, len(p.cached))
+	copy(cached, p.cached)
+	return cached, p.present
+}
+
+func (p *OperatorStatusProvider) refresh() {
+	iops, err := p.client.GetIstioOperators("")
+	if err != nil {
+		log.Warningf("Unable to list IstioOperator resources, keeping the last known component status source: %s", err)
+		return
+	}
+	if len(iops) == 0 {
+		p.mu.Lock()
+		p.present = false
+		p.mu.Unlock()
+		return
+	}
+
+	ics := IstioComponentStatus{}
+	for _, iop := range iops {
+		ics = ics.merge(translateOperatorStatus(iop, p.cluster))
+	}
+
+	p.mu.Lock()
+	p.cached = ics
+	p.present = true
+	p.mu.Unlock()
+}
+
+// watch keeps an IstioOperator watch open for the lifetime of the provider, reconnecting with
+// backoff whenever the watch's result channel closes, so a transient API server disconnect doesn't
+// leave the cached component status frozen forever.
+func (p *OperatorStatusProvider) watch() {
+	backoff := watchInitialBackoff
+
+	for {
+		w, err := p.client.WatchIstioOperators("")
+		if err != nil {
+			log.Warningf("Unable to watch IstioOperator resources, retrying in %s: %s", backoff, err)
+			time.Sleep(backoff)
+			backoff = nextWatchBackoff(backoff)
+			continue
+		}
+
+		backoff = watchInitialBackoff
+		for event := range w.ResultChan() {
+			switch event.Type {
+			case watch.Added, watch.Modified, watch.Deleted:
+				p.refresh()
+			}
+		}
+		w.Stop()
+
+		log.Warningf("IstioOperator watch closed, reconnecting in %s", backoff)
+		time.Sleep(backoff)
+		backoff = nextWatchBackoff(backoff)
+	}
+}
+
+func nextWatchBackoff(backoff time.Duration) time.Duration {
+	backoff *= 2
+	if backoff > watchMaxBackoff {
+		return watchMaxBackoff
+	}
+	return backoff
+}
+
+// translateOperatorStatus converts one IstioOperator's status.componentStatus into Kiali's
+// ComponentStatus vocabulary. Ingress/egress gateways are reported one row per gateway name, since
+// that's how operator profiles with multiple gateways name their components
+// (IngressGateways[].Name), rather than collapsing them into a single "istio-ingressgateway" row.
+func translateOperatorStatus(iop kubernetes.IstioOperator, cluster string) IstioComponentStatus {
+	ics := IstioComponentStatus{}
+
+	if status, found := iop.Status.ComponentStatus["Pilot"]; found {
+		ics = append(ics, ComponentStatus{
+			Name:    "istiod",
+			Status:  operatorStatusToComponentStatus(status),
+			IsCore:  true,
+			Cluster: cluster,
+		})
+	}
+
+	if status, found := iop.Status.ComponentStatus["Cni"]; found {
+		ics = append(ics, ComponentStatus{
+			Name:    "istio-cni-node",
+			Status:  operatorStatusToComponentStatus(status),
+			IsCore:  true,
+			Cluster: cluster,
+		})
+	}
+
+	for name, status := range iop.Status.ComponentStatus {
+		gwName, isIngress := gatewayComponentName(name, "IngressGateways")
+		if isIngress {
+			ics = append(ics, ComponentStatus{
+				Name:    gwName,
+				Status:  operatorStatusToComponentStatus(status),
+				IsCore:  false,
+				Cluster: cluster,
+			})
+			continue
+		}
+		gwName, isEgress := gatewayComponentName(name, "EgressGateways")
+		if isEgress {
+			ics = append(ics, ComponentStatus{
+				Name:    gwName,
+				Status:  operatorStatusToComponentStatus(status),
+				IsCore:  false,
+				Cluster: cluster,
+			})
+		}
+	}
+
+	return ics
+}
+
+// gatewayComponentName recognizes the "IngressGateways.<name>"/"EgressGateways.<name>" keys the
+// operator uses for per-gateway component statuses, and returns the gateway's own name.
+func gatewayComponentName(componentKey, prefix string) (string, bool) {
+	withDot := prefix + "."
+	if len(componentKey) <= len(withDot) || componentKey[:len(withDot)] != withDot {
+		return "", false
+	}
+	return componentKey[len(withDot):], true
+}
+
+func operatorStatusToComponentStatus(operatorStatus string) string {
+	switch operatorStatus {
+	case operatorHealthy:
+		return Healthy
+	case operatorReconciling:
+		return NotReady
+	case operatorError:
+		return Unhealthy
+	default:
+		return Unhealthy
+	}
+}
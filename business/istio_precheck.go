@@ -0,0 +1,393 @@
+package business
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"golang.org/x/sync/errgroup"
+	networking_v1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
+	admission_v1 "k8s.io/api/admissionregistration/v1"
+	core_v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/version"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/models"
+)
+
+// precheckConcurrency bounds how many namespaces IstioPrecheckService inspects at once, so a mesh
+// with hundreds of namespaces doesn't hammer the API server the way an unbounded fan-out would.
+const precheckConcurrency = 10
+
+// PrecheckSeverity mirrors the severity levels `istioctl analyze` uses, so the frontend can reuse
+// the same iconography it already has for IstioCheck messages.
+type PrecheckSeverity string
+
+const (
+	PrecheckInfo    PrecheckSeverity = "Info"
+	PrecheckWarning PrecheckSeverity = "Warning"
+	PrecheckError   PrecheckSeverity = "Error"
+)
+
+// PrecheckFinding is one result of an `istioctl x precheck`-style cluster analysis, identifying a
+// condition that is likely to cause problems on, or shortly after, an Istio upgrade.
+type PrecheckFinding struct {
+	// A short, stable identifier for the kind of problem found, e.g. IST0138. Lets the UI and docs
+	// link to a fuller explanation instead of just showing prose.
+	//
+	// example: IST0138
+	// required: true
+	Code string `json:"code"`
+
+	// required: true
+	Severity PrecheckSeverity `json:"severity"`
+
+	// A human-readable explanation of the finding.
+	//
+	// required: true
+	Message string `json:"message"`
+
+	// The object the finding is about, when it is scoped to a specific resource.
+	Reference *models.IstioValidationKey `json:"reference,omitempty"`
+}
+
+// IstioPrecheckService runs a suite of cluster-side analyzers similar to `istioctl x precheck`,
+// surfacing things that a plain component-status check (Deployment up/down) can't see: API versions
+// the cluster is about to lose, sidecars that fell out of sync with the control plane, and a webhook
+// CA bundle that no longer matches the injector's.
+type IstioPrecheckService struct {
+	k8s kubernetes.ClientInterface
+}
+
+func NewIstioPrecheckService(k8s kubernetes.ClientInterface) IstioPrecheckService {
+	return IstioPrecheckService{k8s: k8s}
+}
+
+// Run fans out every analyzer over an errgroup bounded to precheckConcurrency, so the check scales
+// to meshes with many namespaces without exhausting the API server.
+func (ips *IstioPrecheckService) Run() ([]PrecheckFinding, error) {
+	g, _ := errgroup.WithContext(context.Background())
+	g.SetLimit(precheckConcurrency)
+
+	// findingsChan must be drained concurrently, not just after g.Wait(): the analyzers fan out one
+	// goroutine per namespace, and a mesh producing more findings than the channel's buffer would
+	// block those goroutines forever with nothing left to read them, hanging g.Wait() and the whole
+	// status call with it.
+	findingsChan := make(chan PrecheckFinding, 100)
+	findings := make([]PrecheckFinding, 0)
+	collected := make(chan struct{})
+	go func() {
+		defer close(collected)
+		for finding := range findingsChan {
+			findings = append(findings, finding)
+		}
+	}()
+
+	g.Go(func() error {
+		return ips.checkServerVersion(findingsChan)
+	})
+
+	g.Go(func() error {
+		return ips.checkWebhookCABundle(findingsChan)
+	})
+
+	namespaces, err := ips.k8s.GetNamespaces("")
+	if err != nil {
+		close(findingsChan)
+		<-collected
+		return nil, err
+	}
+
+	for _, ns := range namespaces {
+		ns := ns.Name
+		g.Go(func() error {
+			return ips.checkDeprecatedAPIUsage(ns, findingsChan)
+		})
+		g.Go(func() error {
+			return ips.checkSidecarHealth(ns, findingsChan)
+		})
+	}
+
+	err = g.Wait()
+	close(findingsChan)
+	<-collected
+
+	return findings, err
+}
+
+// checkServerVersion flags a cluster running a Kubernetes version outside the range Kiali was told
+// to expect, which is the single most common cause of a "supported but untested" Istio upgrade.
+func (ips *IstioPrecheckService) checkServerVersion(findingsChan chan<- PrecheckFinding) error {
+	cfg := config.Get().ExternalServices.Istio.Precheck
+	if cfg.MinK8sVersion == "" && cfg.MaxK8sVersion == "" {
+		return nil
+	}
+
+	serverVersion, err := ips.k8s.GetServerVersion()
+	if err != nil {
+		return err
+	}
+
+	v := normalizeK8sVersion(serverVersion)
+	if cfg.MinK8sVersion != "" && k8sVersionLess(v, normalizeK8sVersionString(cfg.MinK8sVersion)) {
+		findingsChan <- PrecheckFinding{
+			Code:     "IST0138",
+			Severity: PrecheckError,
+			Message:  fmt.Sprintf("Kubernetes server version %s is older than the minimum supported version %s", v, cfg.MinK8sVersion),
+		}
+	} else if cfg.MaxK8sVersion != "" && k8sVersionLess(normalizeK8sVersionString(cfg.MaxK8sVersion), v) {
+		findingsChan <- PrecheckFinding{
+			Code:     "IST0138",
+			Severity: PrecheckWarning,
+			Message:  fmt.Sprintf("Kubernetes server version %s is newer than the last version this Istio release was tested against (%s)", v, cfg.MaxK8sVersion),
+		}
+	}
+
+	return nil
+}
+
+func normalizeK8sVersion(v *version.Info) string {
+	return normalizeK8sVersionString(fmt.Sprintf("%s.%s", v.Major, v.Minor))
+}
+
+func normalizeK8sVersionString(v string) string {
+	return strings.TrimSuffix(strings.TrimPrefix(v, "v"), "+")
+}
+
+// k8sVersionLess reports whether Major.Minor version a is older than b. Kubernetes minor versions
+// have reached two digits since 1.10, so comparing "Major.Minor" as plain strings is wrong: e.g.
+// "1.9" < "1.27" is false lexicographically, when 1.9 is in fact the older version. Falls back to a
+// string comparison if either side doesn't parse as Major.Minor, rather than erroring out of the
+// whole precheck over an unexpected version string.
+func k8sVersionLess(a, b string) bool {
+	aMajor, aMinor, aOK := parseK8sVersion(a)
+	bMajor, bMinor, bOK := parseK8sVersion(b)
+	if !aOK || !bOK {
+		return a < b
+	}
+	if aMajor != bMajor {
+		return aMajor < bMajor
+	}
+	return aMinor < bMinor
+}
+
+func parseK8sVersion(v string) (major int, minor int, ok bool) {
+	parts := strings.SplitN(v, ".", 2)
+	if len(parts) != 2 {
+		return 0, 0, false
+	}
+	major, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return 0, 0, false
+	}
+	minor, err = strconv.Atoi(parts[1])
+	if err != nil {
+		return 0, 0, false
+	}
+	return major, minor, true
+}
+
+// deprecatedIstioAPIs lists the legacy API fields Kiali knows are slated for removal. Kept small and
+// explicit on purpose: precheck is meant to be a curated list of "this will break", not a generic
+// linter.
+var deprecatedIstioAPIs = []struct {
+	code    string
+	kind    string
+	field   string
+	message string
+}{
+	{
+		code:    "IST0150",
+		kind:    "VirtualService",
+		field:   "mirror_percent",
+		message: "spec.http[].mirror_percent is deprecated in networking.istio.io/v1alpha3, use mirrorPercentage instead",
+	},
+	{
+		code:    "IST0151",
+		kind:    "EnvoyFilter",
+		field:   "applyTo: CLUSTER_HEADER_MATCH",
+		message: "deprecated EnvoyFilter applyTo value found, this filter will stop matching on upgrade",
+	},
+}
+
+// checkDeprecatedAPIUsage looks for uses of Istio networking config that relies on fields slated for
+// removal, the same class of problem `istioctl analyze`'s deprecation analyzer reports. Checks the
+// typed fields directly rather than pattern-matching the struct's dumped representation, so it can
+// tell "field is actually set" from "the field name happens to appear somewhere in the dump".
+func (ips *IstioPrecheckService) checkDeprecatedAPIUsage(namespace string, findingsChan chan<- PrecheckFinding) error {
+	vss, err := ips.k8s.GetVirtualServices(namespace, "")
+	if err != nil {
+		return err
+	}
+
+	for _, vs := range vss {
+		if usesMirrorPercent(vs) {
+			findingsChan <- PrecheckFinding{
+				Code:     deprecatedIstioAPIs[0].code,
+				Severity: PrecheckWarning,
+				Message:  deprecatedIstioAPIs[0].message,
+				Reference: &models.IstioValidationKey{
+					ObjectType: "virtualservice",
+					Namespace:  namespace,
+					Name:       vs.Name,
+				},
+			}
+		}
+	}
+
+	efs, err := ips.k8s.GetEnvoyFilters(namespace, "")
+	if err != nil {
+		return err
+	}
+
+	for _, ef := range efs {
+		if usesClusterHeaderMatch(ef) {
+			findingsChan <- PrecheckFinding{
+				Code:     deprecatedIstioAPIs[1].code,
+				Severity: PrecheckWarning,
+				Message:  deprecatedIstioAPIs[1].message,
+				Reference: &models.IstioValidationKey{
+					ObjectType: "envoyfilter",
+					Namespace:  namespace,
+					Name:       ef.Name,
+				},
+			}
+		}
+	}
+
+	return nil
+}
+
+// usesMirrorPercent reports whether any http route on the VirtualService still sets the deprecated
+// spec.http[].mirror_percent field, rather than its networking.istio.io/v1alpha3 replacement
+// mirrorPercentage.
+func usesMirrorPercent(vs *networking_v1beta1.VirtualService) bool {
+	for _, route := range vs.Spec.GetHttp() {
+		if route.GetMirrorPercent() != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// usesClusterHeaderMatch reports whether any of the EnvoyFilter's config patches use the deprecated
+// CLUSTER_HEADER_MATCH applyTo value.
+func usesClusterHeaderMatch(ef *networking_v1beta1.EnvoyFilter) bool {
+	for _, patch := range ef.Spec.GetConfigPatches() {
+		if patch.GetApplyTo().String() == "CLUSTER_HEADER_MATCH" {
+			return true
+		}
+	}
+	return false
+}
+
+// checkSidecarHealth pulls Envoy's cluster stats off each sidecar in the namespace and flags
+// endpoints stuck STALE or FAILED, which usually means the proxy lost its xDS connection to istiod
+// and is serving traffic against an out-of-date config snapshot.
+func (ips *IstioPrecheckService) checkSidecarHealth(namespace string, findingsChan chan<- PrecheckFinding) error {
+	pods, err := ips.k8s.GetPods(namespace, "")
+	if err != nil {
+		return err
+	}
+
+	for _, pod := range pods {
+		if !hasEnvoySidecar(pod) {
+			continue
+		}
+
+		stats, err := ips.k8s.GetPodProxy(namespace, pod.Name, "/stats/clusters")
+		if err != nil {
+			// The sidecar not being reachable is itself interesting, but it's covered by the
+			// regular workload health checks; precheck only cares about a reachable-but-stale proxy.
+			continue
+		}
+
+		if stale, failed := countStaleClusters(stats); stale > 0 || failed > 0 {
+			findingsChan <- PrecheckFinding{
+				Code:     "IST0160",
+				Severity: PrecheckError,
+				Message:  fmt.Sprintf("sidecar has %d STALE and %d FAILED Envoy cluster endpoints, it may be disconnected from istiod", stale, failed),
+				Reference: &models.IstioValidationKey{
+					ObjectType: "pod",
+					Namespace:  namespace,
+					Name:       pod.Name,
+				},
+			}
+		}
+	}
+
+	return nil
+}
+
+func hasEnvoySidecar(pod core_v1.Pod) bool {
+	for _, c := range pod.Spec.Containers {
+		if c.Name == "istio-proxy" {
+			return true
+		}
+	}
+	return false
+}
+
+// countStaleClusters scans the plaintext /stats/clusters output for endpoints reporting a STALE or
+// FAILED health flag. The format is one line per stat, e.g. "outbound|80||svc.ns.svc.cluster.local::10.0.0.1:80::health_flags::FAILED".
+func countStaleClusters(stats []byte) (stale int, failed int) {
+	for _, line := range strings.Split(string(stats), "\n") {
+		if !strings.Contains(line, "health_flags") {
+			continue
+		}
+		if strings.Contains(line, "STALE") {
+			stale++
+		}
+		if strings.Contains(line, "FAILED") {
+			failed++
+		}
+	}
+	return stale, failed
+}
+
+// checkWebhookCABundle verifies that the CA bundle the sidecar-injector webhook is configured with
+// still matches what istiod is currently serving. A mismatch here means pods will silently stop
+// getting injected the next time the webhook is invoked.
+func (ips *IstioPrecheckService) checkWebhookCABundle(findingsChan chan<- PrecheckFinding) error {
+	cfg := config.Get()
+
+	webhooks, err := ips.k8s.GetMutatingWebhookConfigurations(labels.Set(map[string]string{"app": "sidecar-injector"}).AsSelector().String())
+	if err != nil {
+		return err
+	}
+
+	istiodCA, err := ips.k8s.GetConfigMap(cfg.IstioNamespace, "istio-ca-root-cert")
+	if err != nil {
+		return err
+	}
+
+	for _, wh := range webhooks {
+		for _, w := range wh.Webhooks {
+			if !matchesCABundle(w, istiodCA.Data["root-cert.pem"]) {
+				findingsChan <- PrecheckFinding{
+					Code:     "IST0170",
+					Severity: PrecheckError,
+					Message:  fmt.Sprintf("webhook %s CA bundle does not match istiod's current root certificate", w.Name),
+					Reference: &models.IstioValidationKey{
+						ObjectType: "mutatingwebhookconfiguration",
+						Namespace:  wh.Namespace,
+						Name:       wh.Name,
+					},
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+func matchesCABundle(webhook admission_v1.MutatingWebhook, rootCert string) bool {
+	if rootCert == "" || len(webhook.ClientConfig.CABundle) == 0 {
+		// Can't meaningfully compare, don't report a false positive.
+		return true
+	}
+	return string(webhook.ClientConfig.CABundle) == rootCert
+}
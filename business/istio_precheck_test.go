@@ -0,0 +1,96 @@
+package business
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	networking_v1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
+	admission_v1 "k8s.io/api/admissionregistration/v1"
+	core_v1 "k8s.io/api/core/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kiali/kiali/kubernetes"
+)
+
+// precheckFakeClient implements kubernetes.ClientInterface by embedding it as a nil interface and
+// overriding only the methods Run's analyzers actually call. Any method we didn't override would
+// panic on a nil-pointer call if invoked, which is fine as long as Run doesn't reach it: the only
+// one left out, GetServerVersion, is called by checkServerVersion only once a minimum or maximum
+// Kubernetes version is configured.
+type precheckFakeClient struct {
+	kubernetes.ClientInterface
+
+	namespaceCount int
+}
+
+func (f *precheckFakeClient) GetNamespaces(labelSelector string) ([]core_v1.Namespace, error) {
+	namespaces := make([]core_v1.Namespace, f.namespaceCount)
+	for i := range namespaces {
+		namespaces[i] = core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: fmt.Sprintf("ns-%d", i)}}
+	}
+	return namespaces, nil
+}
+
+func (f *precheckFakeClient) GetVirtualServices(namespace, labelSelector string) ([]*networking_v1beta1.VirtualService, error) {
+	return nil, nil
+}
+
+func (f *precheckFakeClient) GetEnvoyFilters(namespace, labelSelector string) ([]*networking_v1beta1.EnvoyFilter, error) {
+	return nil, nil
+}
+
+func (f *precheckFakeClient) GetMutatingWebhookConfigurations(labelSelector string) ([]admission_v1.MutatingWebhookConfiguration, error) {
+	return nil, nil
+}
+
+func (f *precheckFakeClient) GetConfigMap(namespace, name string) (*core_v1.ConfigMap, error) {
+	return &core_v1.ConfigMap{Data: map[string]string{}}, nil
+}
+
+func (f *precheckFakeClient) GetPods(namespace, labelSelector string) ([]core_v1.Pod, error) {
+	return []core_v1.Pod{
+		{
+			ObjectMeta: meta_v1.ObjectMeta{Name: namespace + "-pod"},
+			Spec: core_v1.PodSpec{
+				Containers: []core_v1.Container{{Name: "istio-proxy"}},
+			},
+		},
+	}, nil
+}
+
+func (f *precheckFakeClient) GetPodProxy(namespace, pod, path string) ([]byte, error) {
+	return []byte("outbound|80||svc.svc.cluster.local::10.0.0.1:80::health_flags::STALE\n"), nil
+}
+
+// TestRun_DoesNotDeadlockWithManyFindings is a regression test for a bug where findingsChan (a
+// fixed 100-entry buffered channel) was only drained after every analyzer goroutine finished. Once
+// more namespaces than the buffer size produced a finding, every further send blocked forever with
+// nothing reading the channel, and Run() never returned. findingsChan must instead be drained
+// concurrently while the analyzers are still running.
+func TestRun_DoesNotDeadlockWithManyFindings(t *testing.T) {
+	const namespaceCount = 250 // comfortably more than findingsChan's 100-entry buffer
+
+	ips := NewIstioPrecheckService(&precheckFakeClient{namespaceCount: namespaceCount})
+
+	done := make(chan struct{})
+	var findings []PrecheckFinding
+	var err error
+	go func() {
+		findings, err = ips.Run()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Run() did not return within 5s, findingsChan fan-in appears to be deadlocked")
+	}
+
+	if err != nil {
+		t.Fatalf("Run() returned an error: %s", err)
+	}
+	if len(findings) != namespaceCount {
+		t.Fatalf("expected %d findings (one stale-cluster finding per namespace), got %d", namespaceCount, len(findings))
+	}
+}
@@ -2,12 +2,15 @@ package business
 
 import (
 	"fmt"
+	"strings"
 	"sync"
 	"time"
 
+	"golang.org/x/sync/errgroup"
 	apps_v1 "k8s.io/api/apps/v1"
 	core_v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
 
 	"github.com/kiali/kiali/config"
 	"github.com/kiali/kiali/kubernetes"
@@ -16,9 +19,19 @@ import (
 	"github.com/kiali/kiali/util/httputil"
 )
 
-// SvcService deals with fetching istio/kubernetes services related content and convert to kiali model
+// remoteSecretPrefix names the Secrets that the Istio installer writes into the primary cluster for
+// every remote it was registered against (`istioctl x create-remote-secret`).
+const remoteSecretPrefix = "istio-remote-secret-"
+
+// IstioStatusService deals with fetching the health of the Istio control plane and its addons,
+// across every cluster Kiali has API access to.
 type IstioStatusService struct {
-	k8s kubernetes.ClientInterface
+	homeCluster string
+	userClients map[string]kubernetes.ClientInterface
+}
+
+func NewIstioStatusService(homeCluster string, userClients map[string]kubernetes.ClientInterface) IstioStatusService {
+	return IstioStatusService{homeCluster: homeCluster, userClients: userClients}
 }
 
 type ComponentStatus struct {
@@ -39,6 +52,43 @@ type ComponentStatus struct {
 	// example:  true
 	// required: true
 	IsCore bool `json:"is_core"`
+
+	// The istio.io/rev value of the component, when the component is revisioned (e.g. during a canary upgrade).
+	// Empty for components that are not revision-aware.
+	//
+	// example:  canary
+	Revision string `json:"revision,omitempty"`
+
+	// The revision tags (istio.io/tag) currently pointing at Revision, if any. Namespaces labeled with one of
+	// these tags are injected by this revision.
+	//
+	// example:  ["prod", "default"]
+	Tag []string `json:"tag,omitempty"`
+
+	// True when this is the revision the mesh currently considers "default" for untagged injection. Only set
+	// on revision-aware components, so the UI can flag the other rows as stale during a canary upgrade.
+	IsDefaultRevision bool `json:"is_default_revision,omitempty"`
+
+	// The cluster this component was observed on, so a primary-remote or multi-primary mesh doesn't get
+	// its control planes conflated. Empty for addons checked with the global (non cluster-scoped) config.
+	//
+	// example:  east
+	Cluster string `json:"cluster,omitempty"`
+
+	// Discriminates what kind of thing this entry describes. Empty (the default) means a regular
+	// Deployment-backed control plane/addon component; "WorkloadEntry" means a non-Kubernetes
+	// workload (typically a VM) that registered itself, or was statically declared, against a
+	// WorkloadGroup.
+	//
+	// example:  WorkloadEntry
+	Kind string `json:"kind,omitempty"`
+
+	// The topology.istio.io/network value of the cluster this component runs on, when its istio-system
+	// namespace carries one. Empty for a single-network mesh, or when Kiali has no client for the
+	// cluster to read the label from (a remote detected only via its istio-remote-secret-*).
+	//
+	// example:  network-2
+	Network string `json:"network,omitempty"`
 }
 
 type IstioComponentStatus []ComponentStatus
@@ -56,40 +106,133 @@ const (
 	Unreachable string = "Unreachable"
 )
 
-func (iss *IstioStatusService) GetStatus() (IstioComponentStatus, error) {
+const (
+	// IstioRevisionLabel is set by the Istio installer/operator on every revisioned control plane
+	// Deployment/Pod and on the MutatingWebhookConfiguration it owns.
+	IstioRevisionLabel = "istio.io/rev"
+
+	// IstioTagLabel marks a MutatingWebhookConfiguration (and an IstioRevisionTag) as implementing a
+	// named revision tag, e.g. "prod" or "default", that points at the revision in IstioRevisionLabel.
+	IstioTagLabel = "istio.io/tag"
+
+	// defaultRevision is the well-known revision name used by non-revisioned (single control plane)
+	// installs, and is also the tag Istio creates by default.
+	defaultRevision = "default"
+
+	// topologyNetworkLabel is set by the installer on a cluster's istio-system namespace to name the
+	// network it belongs to, the other half (alongside istio-remote-secret-* Secrets) of how a
+	// multi-network mesh's cross-cluster topology is discovered.
+	topologyNetworkLabel = "topology.istio.io/network"
+)
+
+// IstioStatus is the full payload of the Istio status endpoint: the per-component health that was
+// always there, plus the precheck findings a user would otherwise have to run `istioctl x precheck`
+// to see.
+type IstioStatus struct {
+	Components IstioComponentStatus `json:"components"`
+	Findings   []PrecheckFinding    `json:"findings,omitempty"`
+}
+
+func (iss *IstioStatusService) GetStatus() (IstioStatus, error) {
 	if !config.Get().ExternalServices.Istio.ComponentStatuses.Enabled {
-		return IstioComponentStatus{}, nil
+		return IstioStatus{Components: IstioComponentStatus{}}, nil
 	}
 
 	ics, err := iss.getIstioComponentStatus()
 	if err != nil {
-		return nil, err
+		return IstioStatus{}, err
 	}
 
-	return ics.merge(iss.getAddonComponentStatus()), nil
-}
+	ics = ics.merge(iss.getAddonComponentStatus())
+	ics = ics.merge(iss.getRemoteClusterStatus())
 
-func (iss *IstioStatusService) getIstioComponentStatus() (IstioComponentStatus, error) {
-	// Fetching workloads from component namespaces
-	ds, err := iss.getComponentNamespacesWorkloads()
-	if err != nil {
-		return IstioComponentStatus{}, err
+	homeClient, found := iss.userClients[iss.homeCluster]
+	if !found {
+		return IstioStatus{}, fmt.Errorf("unable to find a client for the home cluster [%s]", iss.homeCluster)
 	}
 
-	deploymentStatus, err := iss.getStatusOf(ds)
+	precheck := NewIstioPrecheckService(homeClient)
+	findings, err := precheck.Run()
 	if err != nil {
-		return IstioComponentStatus{}, err
+		// Precheck is a best-effort, additive analysis: don't fail the whole status call (and thus
+		// the dashboard) just because one analyzer couldn't list some resource.
+		log.Warningf("Istio precheck analysis failed: %s", err)
 	}
 
-	istiodStatus, err := iss.getIstiodReachingCheck()
-	if err != nil {
-		return IstioComponentStatus{}, err
+	return IstioStatus{Components: ics, Findings: findings}, nil
+}
+
+// getIstioComponentStatus runs, per cluster and in parallel: the IstioOperator-derived component
+// status when an IstioOperator CR is present (falling back to the Deployment scan otherwise), the
+// istiod reachability check, and the WorkloadEntry/VM health check. Every resulting entry is tagged
+// with the cluster it came from, so a primary-remote or multi-primary mesh shows up as one row set
+// per cluster instead of a single aggregate that silently only reflects the home cluster.
+func (iss *IstioStatusService) getIstioComponentStatus() (IstioComponentStatus, error) {
+	g := new(errgroup.Group)
+	statusChan := make(chan IstioComponentStatus, len(iss.userClients))
+
+	for cluster, client := range iss.userClients {
+		cluster, client := cluster, client
+		g.Go(func() error {
+			// Operator-derived entries are already tagged with cluster by translateOperatorStatus, and
+			// ComponentStatus() hands back a fresh copy of its cache, so the Network tagging below
+			// doesn't write into the provider's cache.
+			deploymentStatus, found := getOperatorStatusProvider(cluster, client).ComponentStatus()
+			if !found {
+				ds, err := iss.getComponentNamespacesWorkloads(cluster, client)
+				if err != nil {
+					return fmt.Errorf("cluster [%s]: %w", cluster, err)
+				}
+
+				deploymentStatus, err = iss.getStatusOf(cluster, ds)
+				if err != nil {
+					return fmt.Errorf("cluster [%s]: %w", cluster, err)
+				}
+			}
+
+			istiodStatus, err := iss.getIstiodReachingCheck(cluster, client)
+			if err != nil {
+				return fmt.Errorf("cluster [%s]: %w", cluster, err)
+			}
+
+			workloadEntryStatus, err := iss.getWorkloadEntryStatus(cluster, client)
+			if err != nil {
+				// VM/WorkloadEntry health is additive: a cluster with no WorkloadGroups configured, or
+				// without access to list them, should still report its regular component status.
+				log.Warningf("Unable to compute WorkloadEntry status on cluster [%s]: %s", cluster, err)
+			}
+
+			deploymentStatus.merge(istiodStatus)
+			deploymentStatus.merge(workloadEntryStatus)
+
+			network, err := iss.getClusterNetwork(client)
+			if err != nil {
+				// The network label is only ever informational, not worth failing the whole cluster's
+				// status over.
+				log.Warningf("Unable to read topology.istio.io/network on cluster [%s]: %s", cluster, err)
+			} else if network != "" {
+				for i := range deploymentStatus {
+					deploymentStatus[i].Network = network
+				}
+			}
+
+			statusChan <- deploymentStatus
+			return nil
+		})
 	}
 
-	return deploymentStatus.merge(istiodStatus), nil
+	err := g.Wait()
+	close(statusChan)
+
+	ics := IstioComponentStatus{}
+	for status := range statusChan {
+		ics.merge(status)
+	}
+
+	return ics, err
 }
 
-func (iss *IstioStatusService) getComponentNamespacesWorkloads() ([]apps_v1.Deployment, error) {
+func (iss *IstioStatusService) getComponentNamespacesWorkloads(cluster string, client kubernetes.ClientInterface) ([]apps_v1.Deployment, error) {
 	var wg sync.WaitGroup
 
 	nss := map[string]bool{}
@@ -109,14 +252,14 @@ func (iss *IstioStatusService) getComponentNamespacesWorkloads() ([]apps_v1.Depl
 				defer wg.Done()
 				var ds []apps_v1.Deployment
 				var err error
-				if IsNamespaceCached(n) {
+				if cluster == iss.homeCluster && IsNamespaceCached(n) {
 					ds, err = kialiCache.GetDeployments(n)
 				} else {
 					// Adding a warning to enable cache for fetching Istio Status.
 					// It should use cache, as it's an intensive operation but we won't fail otherwise
 					// If user doesn't have access to istio namespace AND it doesn't have enabled cache it won't get the Istio status
-					log.Warningf("Kiali has not [%s] namespace cached. It is required to fetch Istio Status correctly", n)
-					ds, err = iss.k8s.GetDeployments(n)
+					log.Warningf("Kiali has not [%s] namespace cached on cluster [%s]. It is required to fetch Istio Status correctly", n, cluster)
+					ds, err = client.GetDeployments(n)
 				}
 				depsChan <- ds
 				errChan <- err
@@ -169,7 +312,7 @@ func istioCoreComponents() map[string]bool {
 	return components
 }
 
-func (iss *IstioStatusService) getStatusOf(ds []apps_v1.Deployment) (IstioComponentStatus, error) {
+func (iss *IstioStatusService) getStatusOf(cluster string, ds []apps_v1.Deployment) (IstioComponentStatus, error) {
 	statusComponents := istioCoreComponents()
 	isc := IstioComponentStatus{}
 	cf := map[string]bool{}
@@ -192,9 +335,10 @@ func (iss *IstioStatusService) getStatusOf(ds []apps_v1.Deployment) (IstioCompon
 		if status := GetDeploymentStatus(d); status != Healthy {
 			// Check status
 			isc = append(isc, ComponentStatus{
-				Name:   d.Name,
-				Status: status,
-				IsCore: isCore,
+				Name:    d.Name,
+				Status:  status,
+				IsCore:  isCore,
+				Cluster: cluster,
 			},
 			)
 		}
@@ -206,9 +350,10 @@ func (iss *IstioStatusService) getStatusOf(ds []apps_v1.Deployment) (IstioCompon
 		if _, found := cf[comp]; !found {
 			componentNotFound += 1
 			isc = append(isc, ComponentStatus{
-				Name:   comp,
-				Status: NotFound,
-				IsCore: isCore,
+				Name:    comp,
+				Status:  NotFound,
+				IsCore:  isCore,
+				Cluster: cluster,
 			})
 		}
 	}
@@ -217,8 +362,8 @@ func (iss *IstioStatusService) getStatusOf(ds []apps_v1.Deployment) (IstioCompon
 	// Warn users that their kiali config might be wrong
 	if componentNotFound == len(statusComponents) {
 		return isc, fmt.Errorf(
-			"Kiali is unable to find any Istio deployment in namespace %s. Are you sure the Istio namespace is configured correctly in Kiali?",
-			config.Get().IstioNamespace)
+			"Kiali is unable to find any Istio deployment in namespace %s on cluster [%s]. Are you sure the Istio namespace is configured correctly in Kiali?",
+			config.Get().IstioNamespace, cluster)
 	}
 
 	return isc, nil
@@ -236,24 +381,44 @@ func GetDeploymentStatus(d apps_v1.Deployment) string {
 	return status
 }
 
+// getAddonComponentStatus checks reachability of the globally configured addons (the common case:
+// one Prometheus/Grafana/tracing backend shared by the whole mesh). Clusters that define their own
+// cluster-scoped addon URL in config.Clusters are checked again with that override, so per-cluster
+// observability stacks are reported per cluster instead of only ever reflecting the home cluster's.
 func (iss *IstioStatusService) getAddonComponentStatus() IstioComponentStatus {
+	ics := IstioComponentStatus{}
+	ics = ics.merge(checkAddons(config.Get().ExternalServices, ""))
+
+	for cluster, cfg := range config.Get().Clusters {
+		if cluster == iss.homeCluster {
+			continue
+		}
+		if cfg.ExternalServices == nil {
+			continue
+		}
+		ics = ics.merge(checkAddons(*cfg.ExternalServices, cluster))
+	}
+
+	return ics
+}
+
+func checkAddons(extServices config.ExternalServices, cluster string) IstioComponentStatus {
 	var wg sync.WaitGroup
 	wg.Add(4)
 
 	staChan := make(chan IstioComponentStatus, 4)
-	extServices := config.Get().ExternalServices
 	ics := IstioComponentStatus{}
 
-	go getAddonStatus("prometheus", true, extServices.Prometheus.URL, &extServices.Prometheus.Auth, true, staChan, &wg)
-	go getAddonStatus("grafana", extServices.Grafana.Enabled, extServices.Grafana.InClusterURL, &extServices.Grafana.Auth, extServices.Grafana.IsCoreComponent, staChan, &wg)
-	go getAddonStatus("jaeger", extServices.Tracing.Enabled, extServices.Tracing.InClusterURL, &extServices.Tracing.Auth, extServices.Tracing.IsCoreComponent, staChan, &wg)
+	go getAddonStatus("prometheus", true, extServices.Prometheus.URL, &extServices.Prometheus.Auth, true, cluster, staChan, &wg)
+	go getAddonStatus("grafana", extServices.Grafana.Enabled, extServices.Grafana.InClusterURL, &extServices.Grafana.Auth, extServices.Grafana.IsCoreComponent, cluster, staChan, &wg)
+	go getAddonStatus("jaeger", extServices.Tracing.Enabled, extServices.Tracing.InClusterURL, &extServices.Tracing.Auth, extServices.Tracing.IsCoreComponent, cluster, staChan, &wg)
 
 	// Custom dashboards may use the main Prometheus config
 	customProm := extServices.CustomDashboards.Prometheus
 	if customProm.URL == "" {
 		customProm = extServices.Prometheus
 	}
-	go getAddonStatus("custom dashboards", extServices.CustomDashboards.Enabled, customProm.URL, &customProm.Auth, extServices.CustomDashboards.IsCoreComponent, staChan, &wg)
+	go getAddonStatus("custom dashboards", extServices.CustomDashboards.Enabled, customProm.URL, &customProm.Auth, extServices.CustomDashboards.IsCoreComponent, cluster, staChan, &wg)
 
 	wg.Wait()
 
@@ -265,41 +430,112 @@ func (iss *IstioStatusService) getAddonComponentStatus() IstioComponentStatus {
 	return ics
 }
 
-func (iss *IstioStatusService) getIstiodReachingCheck() (IstioComponentStatus, error) {
+// getClusterNetwork reads the topology.istio.io/network label off a cluster's istio-system
+// namespace, the label the installer sets to name the network a cluster belongs to in a
+// multi-network mesh. Returns "" without error for a single-network install, where the label is
+// never set.
+func (iss *IstioStatusService) getClusterNetwork(client kubernetes.ClientInterface) (string, error) {
+	ns, err := client.GetNamespace(config.Get().IstioNamespace)
+	if err != nil {
+		return "", err
+	}
+	return ns.Labels[topologyNetworkLabel], nil
+}
+
+// getRemoteClusterStatus detects cross-cluster control plane topology two ways: the
+// istio-remote-secret-* Secrets the installer writes into the home cluster's Istio namespace (which
+// name a remote Kiali has no client for, and so reports a synthetic "istiod-remote" component as
+// Unreachable for), and the topology.istio.io/network label on the istio-system namespace of every
+// cluster Kiali *does* have a client for, which getIstioComponentStatus tags onto that cluster's
+// regular components directly.
+func (iss *IstioStatusService) getRemoteClusterStatus() IstioComponentStatus {
+	ics := IstioComponentStatus{}
+
+	homeClient, found := iss.userClients[iss.homeCluster]
+	if !found {
+		return ics
+	}
+
+	remotes, err := iss.detectRemoteClusters(homeClient)
+	if err != nil {
+		log.Warningf("Unable to detect remote cluster secrets: %s", err)
+		return ics
+	}
+
+	for _, remote := range remotes {
+		if _, managed := iss.userClients[remote]; managed {
+			// Already fanned out to directly in getIstioComponentStatus.
+			continue
+		}
+
+		ics = append(ics, ComponentStatus{
+			Name:    "istiod-remote",
+			Status:  Unreachable,
+			IsCore:  true,
+			Cluster: remote,
+		})
+	}
+
+	return ics
+}
+
+func (iss *IstioStatusService) detectRemoteClusters(homeClient kubernetes.ClientInterface) ([]string, error) {
+	secrets, err := homeClient.GetSecrets(config.Get().IstioNamespace, "")
+	if err != nil {
+		return nil, err
+	}
+
+	remotes := make([]string, 0, len(secrets))
+	for _, secret := range secrets {
+		if name, ok := strings.CutPrefix(secret.Name, remoteSecretPrefix); ok {
+			remotes = append(remotes, name)
+		}
+	}
+
+	return remotes, nil
+}
+
+// istiodRevision groups the istiod pods that share the same istio.io/rev label, so that a canary
+// upgrade (several control planes living side by side) is reported as several components instead of
+// one confusing aggregate.
+type istiodRevision struct {
+	revision string
+	tags     []string
+	pods     []*core_v1.Pod
+}
+
+func (iss *IstioStatusService) getIstiodReachingCheck(cluster string, client kubernetes.ClientInterface) (IstioComponentStatus, error) {
 	cfg := config.Get()
 
-	istiods, err := iss.k8s.GetPods(cfg.IstioNamespace, labels.Set(map[string]string{"app": "istiod"}).String())
+	istiods, err := client.GetPods(cfg.IstioNamespace, labels.Set(map[string]string{"app": "istiod"}).String())
 	if err != nil {
 		return nil, err
 	}
 
-	healthyIstiods := make([]*core_v1.Pod, 0, len(istiods))
-	for i, istiod := range istiods {
-		if istiod.Status.Phase == "Running" {
-			healthyIstiods = append(healthyIstiods, &istiods[i])
+	revisions := groupIstiodsByRevision(istiods)
+
+	tagsByRevision, err := iss.getRevisionTags(client)
+	if err != nil {
+		// Tag resolution is best-effort: a missing/forbidden MutatingWebhookConfiguration list
+		// shouldn't take down the whole status check, it just means we can't label the rows with tags.
+		log.Warningf("Unable to resolve istio.io/tag revision tags on cluster [%s]: %s", cluster, err)
+	} else {
+		for rev, revStatus := range revisions {
+			revStatus.tags = tagsByRevision[rev]
 		}
 	}
 
+	defaultRev := defaultIstiodRevision(revisions, tagsByRevision)
+
 	wg := sync.WaitGroup{}
-	wg.Add(len(healthyIstiods))
-	syncChan := make(chan ComponentStatus, len(healthyIstiods))
+	wg.Add(len(revisions))
+	syncChan := make(chan ComponentStatus, len(revisions))
 
-	for _, istiod := range healthyIstiods {
-		go func(name, namespace string) {
+	for _, revStatus := range revisions {
+		go func(revStatus *istiodRevision) {
 			defer wg.Done()
-			// Using the proxy method to make sure that K8s API has access to the Istio Control Plane namespace.
-			// By proxying one Istiod, we ensure that the following connection is allowed:
-			// Kiali -> K8s API (proxy) -> istiod
-			// This scenario is no obvious for private clusters (like GKE private cluster)
-			_, err := iss.k8s.GetPodProxy(namespace, name, "/ready")
-			if err != nil {
-				syncChan <- ComponentStatus{
-					Name:   name,
-					Status: Unreachable,
-					IsCore: true,
-				}
-			}
-		}(istiod.Name, istiod.Namespace)
+			syncChan <- checkIstiodRevision(client, cluster, revStatus, revStatus.revision == defaultRev)
+		}(revStatus)
 	}
 
 	wg.Wait()
@@ -312,7 +548,117 @@ func (iss *IstioStatusService) getIstiodReachingCheck() (IstioComponentStatus, e
 	return ics, nil
 }
 
-func getAddonStatus(name string, enabled bool, url string, auth *config.Auth, isCore bool, staChan chan<- IstioComponentStatus, wg *sync.WaitGroup) {
+// checkIstiodRevision picks one running pod of the revision and proxies "/ready" through the K8s API,
+// the same way a single-revision install is checked, then reports the outcome tagged with the
+// revision and the cluster it was observed on.
+func checkIstiodRevision(client kubernetes.ClientInterface, cluster string, revStatus *istiodRevision, isDefault bool) ComponentStatus {
+	name := "istiod"
+	if revStatus.revision != defaultRevision {
+		name = fmt.Sprintf("istiod-%s", revStatus.revision)
+	}
+
+	status := ComponentStatus{
+		Name:              name,
+		IsCore:            true,
+		Revision:          revStatus.revision,
+		Tag:               revStatus.tags,
+		IsDefaultRevision: isDefault,
+		Cluster:           cluster,
+	}
+
+	healthyPod := firstRunningPod(revStatus.pods)
+	if healthyPod == nil {
+		status.Status = Unreachable
+		return status
+	}
+
+	// Using the proxy method to make sure that K8s API has access to the Istio Control Plane namespace.
+	// By proxying one Istiod, we ensure that the following connection is allowed:
+	// Kiali -> K8s API (proxy) -> istiod
+	// This scenario is no obvious for private clusters (like GKE private cluster)
+	if _, err := client.GetPodProxy(healthyPod.Namespace, healthyPod.Name, "/ready"); err != nil {
+		status.Status = Unreachable
+		return status
+	}
+
+	status.Status = Healthy
+	return status
+}
+
+func groupIstiodsByRevision(pods []core_v1.Pod) map[string]*istiodRevision {
+	revisions := map[string]*istiodRevision{}
+	for i := range pods {
+		pod := &pods[i]
+		rev := labels.Set(pod.Labels).Get(IstioRevisionLabel)
+		if rev == "" {
+			rev = defaultRevision
+		}
+		if _, found := revisions[rev]; !found {
+			revisions[rev] = &istiodRevision{revision: rev}
+		}
+		revisions[rev].pods = append(revisions[rev].pods, pod)
+	}
+	return revisions
+}
+
+func firstRunningPod(pods []*core_v1.Pod) *core_v1.Pod {
+	for _, pod := range pods {
+		if pod.Status.Phase == "Running" {
+			return pod
+		}
+	}
+	return nil
+}
+
+// getRevisionTags resolves which revision tags (istio.io/tag) currently point at which revision
+// (istio.io/rev), by reading the label pair off the MutatingWebhookConfigurations that the Istio
+// installer creates for every IstioRevisionTag.
+func (iss *IstioStatusService) getRevisionTags(client kubernetes.ClientInterface) (map[string][]string, error) {
+	// We only care that IstioTagLabel is set, not its value, so this has to be an "exists"
+	// requirement. labels.Set{...}.AsSelector() would instead build an equality selector that only
+	// matches the label being set to the empty string, which no real istio.io/tag value ever is.
+	hasTagLabel, err := labels.NewRequirement(IstioTagLabel, selection.Exists, nil)
+	if err != nil {
+		return nil, err
+	}
+	selector := labels.NewSelector().Add(*hasTagLabel)
+
+	webhooks, err := client.GetMutatingWebhookConfigurations(selector.String())
+	if err != nil {
+		return nil, err
+	}
+
+	tagsByRevision := map[string][]string{}
+	for _, wh := range webhooks {
+		tag := labels.Set(wh.Labels).Get(IstioTagLabel)
+		rev := labels.Set(wh.Labels).Get(IstioRevisionLabel)
+		if tag == "" || rev == "" {
+			continue
+		}
+		tagsByRevision[rev] = append(tagsByRevision[rev], tag)
+	}
+
+	return tagsByRevision, nil
+}
+
+// defaultIstiodRevision decides which revision the mesh currently treats as the default for
+// untagged namespace injection: the revision the "default" tag points to, falling back to the
+// literal "default" revision name used by non-canary installs.
+func defaultIstiodRevision(revisions map[string]*istiodRevision, tagsByRevision map[string][]string) string {
+	for rev, tags := range tagsByRevision {
+		for _, tag := range tags {
+			if tag == defaultRevision {
+				return rev
+			}
+		}
+	}
+	if _, found := revisions[defaultRevision]; found {
+		return defaultRevision
+	}
+	return ""
+}
+
+func getAddonStatus(name string, enabled bool, url string, auth *config.Auth, isCore bool, cluster string, staChan chan<- IstioComponentStatus, wg *sync.WaitGroup) {
 	defer wg.Done()
 
 	if auth.UseKialiToken {
@@ -333,9 +679,10 @@ func getAddonStatus(name string, enabled bool, url string, auth *config.Auth, is
 	if err != nil || statusCode > 399 {
 		staChan <- IstioComponentStatus{
 			ComponentStatus{
-				Name:   name,
-				Status: Unreachable,
-				IsCore: isCore,
+				Name:    name,
+				Status:  Unreachable,
+				IsCore:  isCore,
+				Cluster: cluster,
 			},
 		}
 	}
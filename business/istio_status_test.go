@@ -0,0 +1,58 @@
+package business
+
+import (
+	"testing"
+
+	admission_v1 "k8s.io/api/admissionregistration/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kiali/kiali/kubernetes"
+)
+
+// revisionTagsFakeClient implements kubernetes.ClientInterface by embedding it as a nil interface
+// and overriding only GetMutatingWebhookConfigurations, the single call getRevisionTags makes.
+type revisionTagsFakeClient struct {
+	kubernetes.ClientInterface
+
+	// gotSelector records the selector string getRevisionTags built, so the test can assert it's an
+	// "exists" selector rather than the equals-empty-string selector this is a regression test for.
+	gotSelector string
+}
+
+func (f *revisionTagsFakeClient) GetMutatingWebhookConfigurations(labelSelector string) ([]admission_v1.MutatingWebhookConfiguration, error) {
+	f.gotSelector = labelSelector
+	return []admission_v1.MutatingWebhookConfiguration{
+		{
+			ObjectMeta: meta_v1.ObjectMeta{
+				Name: "istio-revision-tag-prod",
+				Labels: map[string]string{
+					IstioTagLabel:      "prod",
+					IstioRevisionLabel: "1-20-0",
+				},
+			},
+		},
+	}, nil
+}
+
+// TestGetRevisionTags_MatchesNonEmptyTagValues is a regression test for a selector bug where
+// getRevisionTags built an equality selector (istio.io/tag=) instead of an "exists" selector, which
+// only matched a webhook whose istio.io/tag label was literally the empty string. Real tag values
+// (prod, canary, default, ...) are never empty, so the equality selector never matched anything.
+func TestGetRevisionTags_MatchesNonEmptyTagValues(t *testing.T) {
+	fake := &revisionTagsFakeClient{}
+	iss := &IstioStatusService{}
+
+	tagsByRevision, err := iss.getRevisionTags(fake)
+	if err != nil {
+		t.Fatalf("getRevisionTags returned an error: %s", err)
+	}
+
+	if fake.gotSelector != "istio.io/tag" {
+		t.Fatalf("expected an \"exists\" selector (\"istio.io/tag\"), got %q", fake.gotSelector)
+	}
+
+	tags := tagsByRevision["1-20-0"]
+	if len(tags) != 1 || tags[0] != "prod" {
+		t.Fatalf("expected revision 1-20-0 to be tagged [prod], got %v", tags)
+	}
+}
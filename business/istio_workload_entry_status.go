@@ -0,0 +1,187 @@
+package business
+
+import (
+	"encoding/json"
+	"fmt"
+
+	core_v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/log"
+)
+
+// autoRegistrationGroupAnnotation is set by istiod on every WorkloadEntry it auto-registers, naming
+// the WorkloadGroup the VM onboarded against.
+const autoRegistrationGroupAnnotation = "istio.io/autoRegistrationGroup"
+
+// workloadControllerAnnotation is set by istiod on auto-registered WorkloadEntries, naming the
+// istiod pod responsible for health-checking and eventually garbage-collecting the entry. If that
+// pod is gone, the entry's health state is stale and nothing will ever clean it up.
+const workloadControllerAnnotation = "istio.io/workloadController"
+
+// endpointzResponse is the relevant subset of istiod's /debug/endpointz output: one row per
+// endpoint currently known to the xDS cache, with its address, which fetchEndpointz keys the healthy
+// set by so a WorkloadEntry can be matched against it directly.
+type endpointzResponse []struct {
+	Address string `json:"Address"`
+	Healthy bool   `json:"Healthy"`
+}
+
+// getWorkloadEntryStatus surfaces VM/WorkloadEntry health alongside the regular control-plane
+// components, so operators see onboarding problems (an entry that never registers, or a stale
+// auto-registration left behind by a recycled istiod) in the same panel.
+func (iss *IstioStatusService) getWorkloadEntryStatus(cluster string, client kubernetes.ClientInterface) (IstioComponentStatus, error) {
+	groups, err := client.GetWorkloadGroups("")
+	if err != nil {
+		return nil, err
+	}
+	if len(groups) == 0 {
+		return IstioComponentStatus{}, nil
+	}
+
+	entries, err := client.GetWorkloadEntries("")
+	if err != nil {
+		return nil, err
+	}
+
+	liveIstiods, err := client.GetPods(config.Get().IstioNamespace, labels.Set(map[string]string{"app": "istiod"}).String())
+	if err != nil {
+		return nil, err
+	}
+	livePodNames := map[string]bool{}
+	for _, pod := range liveIstiods {
+		livePodNames[pod.Name] = true
+	}
+
+	endpoints, err := fetchEndpointz(client, liveIstiods)
+	if err != nil {
+		// A mesh without WorkloadEntries registered yet, or with no reachable istiod, shouldn't hide
+		// the staleness findings below, so keep going with an empty endpoint set.
+		log.Warningf("Unable to fetch istiod /debug/endpointz on cluster [%s]: %s", cluster, err)
+	}
+
+	groupsByName := map[string]bool{}
+	for _, g := range groups {
+		groupsByName[g.Namespace+"/"+g.Name] = true
+	}
+	matchers := buildGroupMatchers(groups)
+
+	ics := IstioComponentStatus{}
+	for _, entry := range entries {
+		// Auto-registered entries record the WorkloadGroup they onboarded against directly; a
+		// manually declared (static) WorkloadEntry carries no such annotation and has to be
+		// correlated the way Istio itself treats it as belonging to a group: by matching the
+		// group's template labels against the entry's own labels, within the same namespace.
+		var hasParentGroup bool
+		if groupName := entry.Annotations[autoRegistrationGroupAnnotation]; groupName != "" {
+			hasParentGroup = groupsByName[entry.Namespace+"/"+groupName]
+		} else {
+			hasParentGroup = matchParentGroup(entry, matchers)
+		}
+
+		if !hasParentGroup {
+			// Orphaned: no WorkloadGroup (registered-against, or label-matching) covers this entry.
+			ics = append(ics, ComponentStatus{
+				Kind:    "WorkloadEntry",
+				Name:    fmt.Sprintf("%s/%s", entry.Namespace, entry.Name),
+				Status:  Unhealthy,
+				Cluster: cluster,
+			})
+			continue
+		}
+
+		if controller := entry.Annotations[workloadControllerAnnotation]; controller != "" && !livePodNames[controller] {
+			// The istiod pod that owns this entry's health checking is gone. Nothing will mark it
+			// unhealthy or clean it up on its own, so flag it here.
+			ics = append(ics, ComponentStatus{
+				Kind:    "WorkloadEntry",
+				Name:    fmt.Sprintf("%s/%s", entry.Namespace, entry.Name),
+				Status:  Unhealthy,
+				Cluster: cluster,
+			})
+			continue
+		}
+
+		status := Unhealthy
+		if endpoints[entry.Spec.Address] {
+			status = Healthy
+		}
+		ics = append(ics, ComponentStatus{
+			Kind:    "WorkloadEntry",
+			Name:    fmt.Sprintf("%s/%s", entry.Namespace, entry.Name),
+			Status:  status,
+			Cluster: cluster,
+		})
+	}
+
+	return ics, nil
+}
+
+// groupMatcher pairs a WorkloadGroup with the selector derived from its pod template labels, so a
+// WorkloadEntry can be tested against it without rebuilding the selector on every entry.
+type groupMatcher struct {
+	namespace string
+	selector  labels.Selector
+}
+
+// buildGroupMatchers derives one label selector per WorkloadGroup from its pod template labels
+// (WorkloadGroup.Spec.Template.Labels), the same labels Istio injects into the entries it
+// auto-registers against that group, and the labels a static WorkloadEntry is expected to declare
+// to be considered part of it.
+func buildGroupMatchers(groups []kubernetes.WorkloadGroup) []groupMatcher {
+	matchers := make([]groupMatcher, 0, len(groups))
+	for _, g := range groups {
+		matchers = append(matchers, groupMatcher{
+			namespace: g.Namespace,
+			selector:  labels.SelectorFromSet(labels.Set(g.Spec.Template.Labels)),
+		})
+	}
+	return matchers
+}
+
+// matchParentGroup reports whether any WorkloadGroup in the entry's namespace selects it via its
+// template labels.
+func matchParentGroup(entry kubernetes.WorkloadEntry, matchers []groupMatcher) bool {
+	entryLabels := labels.Set(entry.Labels)
+	for _, m := range matchers {
+		if m.namespace == entry.Namespace && m.selector.Matches(entryLabels) {
+			return true
+		}
+	}
+	return false
+}
+
+// fetchEndpointz proxies /debug/endpointz through the first reachable istiod pod and returns the
+// set of addresses istiod currently considers healthy endpoints.
+func fetchEndpointz(client kubernetes.ClientInterface, istiods []core_v1.Pod) (map[string]bool, error) {
+	pods := make([]*core_v1.Pod, 0, len(istiods))
+	for i := range istiods {
+		pods = append(pods, &istiods[i])
+	}
+
+	istiod := firstRunningPod(pods)
+	if istiod == nil {
+		return nil, fmt.Errorf("no running istiod pod to proxy /debug/endpointz through")
+	}
+
+	raw, err := client.GetPodProxy(istiod.Namespace, istiod.Name, "/debug/endpointz")
+	if err != nil {
+		return nil, err
+	}
+
+	var resp endpointzResponse
+	if err := json.Unmarshal(raw, &resp); err != nil {
+		return nil, err
+	}
+
+	healthy := map[string]bool{}
+	for _, ep := range resp {
+		if ep.Healthy {
+			healthy[ep.Address] = true
+		}
+	}
+
+	return healthy, nil
+}